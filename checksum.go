@@ -0,0 +1,201 @@
+package copy
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+
+	"lukechampine.com/blake3"
+)
+
+// ChecksumAlgo selects the digest algorithm used by [WithChecksum].
+type ChecksumAlgo int
+
+const (
+	SHA256 ChecksumAlgo = iota
+	BLAKE3
+)
+
+// CopyResult is populated through [WithChecksum] once [Copy] returns
+// successfully. Digests maps every copied regular file's path, relative to
+// src, to its content digest; symlinks and directories only contribute to
+// Tree. Tree is a single Merkle-style digest of the whole copied
+// tree, letting a caller skip a redundant copy when the destination already
+// carries a matching Tree digest.
+type CopyResult struct {
+	Digests map[string]string
+	Tree    string
+}
+
+// checksumState accumulates per-entry digests while a tree is walked and
+// combines them into the aggregate CopyResult once the copy finishes.
+type checksumState struct {
+	algo     ChecksumAlgo
+	result   *CopyResult
+	mu       sync.Mutex
+	content  map[string][]byte
+	entries  map[string][]byte
+	children map[string][]string
+}
+
+func newChecksumState(algo ChecksumAlgo, result *CopyResult) *checksumState {
+	return &checksumState{
+		algo:     algo,
+		result:   result,
+		content:  make(map[string][]byte),
+		entries:  make(map[string][]byte),
+		children: make(map[string][]string),
+	}
+}
+
+func newDigest(algo ChecksumAlgo) (hash.Hash, error) {
+	switch algo {
+	case SHA256:
+		return sha256.New(), nil
+	case BLAKE3:
+		return blake3.New(32, nil), nil
+	default:
+		return nil, fmt.Errorf("copy: unknown checksum algorithm: %d", algo)
+	}
+}
+
+// link registers rel as a child of its parent directory so the aggregate
+// digest can later be combined bottom-up.
+func (c *checksumState) link(rel string) {
+	if rel == "." {
+		return
+	}
+
+	parent := path.Dir(rel)
+	c.children[parent] = append(c.children[parent], rel)
+
+	if _, ok := c.children[rel]; !ok {
+		c.children[rel] = nil
+	}
+}
+
+// addFile records the digest of a regular file's content, computed inline
+// while it was copied, together with its entry digest. Safe for concurrent
+// use by the copyTree worker pool.
+func (c *checksumState) addFile(rel string, mode fs.FileMode, size int64, content []byte) error {
+	h, err := newDigest(c.algo)
+	if err != nil {
+		return err
+	}
+
+	writeHeader(h, mode, size, rel)
+	h.Write(content)
+	entry := h.Sum(nil)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.link(rel)
+	c.content[rel] = content
+	c.entries[rel] = entry
+
+	return nil
+}
+
+// addSymlink records the entry digest of a symlink created via
+// [WithNoFollow]: mode || path || link target. Safe for concurrent use by
+// the copyTree worker pool.
+func (c *checksumState) addSymlink(rel string, mode fs.FileMode, target string) error {
+	h, err := newDigest(c.algo)
+	if err != nil {
+		return err
+	}
+
+	writeHeader(h, mode, -1, rel)
+	h.Write([]byte(target))
+	entry := h.Sum(nil)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.link(rel)
+	c.entries[rel] = entry
+
+	return nil
+}
+
+// addDir registers a directory so it participates in the aggregate digest
+// even if it turns out to be empty. Safe for concurrent use by the
+// copyTree worker pool.
+func (c *checksumState) addDir(rel string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.link(rel)
+}
+
+// finalize combines every recorded entry into the aggregate tree digest,
+// starting from root (use "." for the tree root), and fills in result.
+func (c *checksumState) finalize(root string) error {
+	digest, err := c.digestOf(root)
+	if err != nil {
+		return err
+	}
+
+	digests := make(map[string]string, len(c.content))
+	for rel, sum := range c.content {
+		digests[rel] = hex.EncodeToString(sum)
+	}
+
+	c.result.Digests = digests
+	c.result.Tree = hex.EncodeToString(digest)
+
+	return nil
+}
+
+func (c *checksumState) digestOf(rel string) ([]byte, error) {
+	if d, ok := c.entries[rel]; ok {
+		return d, nil
+	}
+
+	children := append([]string(nil), c.children[rel]...)
+	sort.Strings(children)
+
+	h, err := newDigest(c.algo)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		d, err := c.digestOf(child)
+		if err != nil {
+			return nil, err
+		}
+
+		h.Write(d)
+	}
+
+	d := h.Sum(nil)
+	c.entries[rel] = d
+
+	return d, nil
+}
+
+// writeHeader feeds mode, size and rel into h in a fixed, deterministic
+// layout. size is omitted (pass -1) for entries such as symlinks that have
+// no meaningful size of their own.
+func writeHeader(h hash.Hash, mode fs.FileMode, size int64, rel string) {
+	var buf [12]byte
+
+	binary.BigEndian.PutUint32(buf[0:4], uint32(mode))
+
+	if size < 0 {
+		h.Write(buf[0:4])
+	} else {
+		binary.BigEndian.PutUint64(buf[4:12], uint64(size))
+		h.Write(buf[:])
+	}
+
+	h.Write([]byte(rel))
+}
@@ -0,0 +1,120 @@
+package copy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestCopyChecksum(t *testing.T) {
+	src, err := createSourceTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+
+	dst := path.Join(os.TempDir(), "destination-checksum")
+	defer os.RemoveAll(dst)
+
+	var result CopyResult
+
+	if err := Copy(
+		t.Context(), src, dst, WithChecksum(SHA256, &result),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range files {
+		if _, ok := result.Digests[f]; !ok {
+			t.Errorf("%s: missing digest", f)
+		}
+	}
+
+	if result.Tree == "" {
+		t.Error("expected a non-empty aggregate tree digest")
+	}
+}
+
+// TestCopyChecksumIncludePatterns guards against the aggregate digest being
+// computed as if the tree were empty when an ancestor directory of a
+// copied file doesn't itself match the include patterns.
+func TestCopyChecksumIncludePatterns(t *testing.T) {
+	src, err := createSourceTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+
+	dst := path.Join(os.TempDir(), "destination-checksum-include")
+	defer os.RemoveAll(dst)
+
+	var result CopyResult
+
+	if err := Copy(
+		t.Context(), src, dst,
+		WithIncludePatterns([]string{"**/file2"}),
+		WithChecksum(SHA256, &result),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Digests) != 1 {
+		t.Fatalf("expected 1 digest, got %d: %+v", len(result.Digests), result.Digests)
+	}
+
+	empty := sha256.Sum256(nil)
+	if result.Tree == hex.EncodeToString(empty[:]) {
+		t.Error("aggregate tree digest must not be the empty-tree digest when a file was copied")
+	}
+}
+
+// TestCopyChecksumDeterministic checks that the aggregate tree digest is
+// stable across repeated copies of the same tree and changes when a file's
+// content changes.
+func TestCopyChecksumDeterministic(t *testing.T) {
+	src, err := createSourceTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+
+	dst1 := path.Join(os.TempDir(), "destination-checksum-1")
+	dst2 := path.Join(os.TempDir(), "destination-checksum-2")
+	defer os.RemoveAll(dst1)
+	defer os.RemoveAll(dst2)
+
+	var r1, r2 CopyResult
+
+	if err := Copy(t.Context(), src, dst1, WithChecksum(SHA256, &r1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Copy(t.Context(), src, dst2, WithChecksum(SHA256, &r2)); err != nil {
+		t.Fatal(err)
+	}
+
+	if r1.Tree != r2.Tree {
+		t.Errorf("expected deterministic tree digest: %s != %s", r1.Tree, r2.Tree)
+	}
+
+	if err := os.WriteFile(
+		path.Join(src, "file1"), []byte("different data"), 0o755,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	dst3 := path.Join(os.TempDir(), "destination-checksum-3")
+	defer os.RemoveAll(dst3)
+
+	var r3 CopyResult
+
+	if err := Copy(t.Context(), src, dst3, WithChecksum(SHA256, &r3)); err != nil {
+		t.Fatal(err)
+	}
+
+	if r3.Tree == r1.Tree {
+		t.Error("expected a different tree digest after changing file content")
+	}
+}
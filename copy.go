@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"io/fs"
 	"os"
@@ -11,8 +12,11 @@ import (
 	"path/filepath"
 	"strings"
 
+	"golang.org/x/sync/errgroup"
+
 	"github.com/emar-kar/copy/v2/internal/contextio"
 	"github.com/emar-kar/copy/v2/internal/utils"
+	"github.com/emar-kar/copy/v2/internal/vfs"
 )
 
 var ErrSame = errors.New("same location")
@@ -23,12 +27,18 @@ func Copy(ctx context.Context, src, dst string, opts ...optFunc) error {
 		fn(opt)
 	}
 
+	if err := opt.compilePatterns(); err != nil {
+		return err
+	}
+
 	var (
 		link bool
 		err  error
 	)
 
-	src, link, err = utils.ResolvePath(src)
+	origSrc := src
+
+	src, link, err = utils.ResolvePath(opt.sourceFS, src)
 	if err != nil {
 		return err
 	}
@@ -37,12 +47,12 @@ func Copy(ctx context.Context, src, dst string, opts ...optFunc) error {
 		return nil
 	}
 
-	srcInfo, err := os.Stat(src)
+	srcInfo, err := opt.sourceFS.Stat(src)
 	if err != nil {
 		return err
 	}
 
-	if dstInfo, err := os.Stat(dst); !errors.Is(err, fs.ErrNotExist) {
+	if dstInfo, err := opt.destFS.Stat(dst); !errors.Is(err, fs.ErrNotExist) {
 		if os.SameFile(srcInfo, dstInfo) {
 			return ErrSame
 		}
@@ -51,14 +61,14 @@ func Copy(ctx context.Context, src, dst string, opts ...optFunc) error {
 			return fmt.Errorf("%s: %w", dst, fs.ErrExist)
 		}
 
-		if err := os.RemoveAll(dst); err != nil {
+		if err := opt.destFS.RemoveAll(dst); err != nil {
 			return err
 		}
 	}
 
 	dstDir, fileName := path.Split(dst)
 
-	if err := os.MkdirAll(dstDir, srcInfo.Mode()); err != nil {
+	if err := opt.destFS.MkdirAll(dstDir, srcInfo.Mode()); err != nil {
 		return err
 	}
 
@@ -66,25 +76,74 @@ func Copy(ctx context.Context, src, dst string, opts ...optFunc) error {
 		dst = path.Join(dstDir, path.Base(src))
 	}
 
-	if link && opt.noFollow {
-		return os.Symlink(src, dst)
+	switch {
+	case link && opt.noFollow:
+		if err := opt.destFS.Symlink(src, dst); err != nil {
+			return err
+		}
+
+		if opt.preserve != 0 && isOSFS(opt.sourceFS) && isOSFS(opt.destFS) {
+			// srcInfo describes the link's target; the link itself is what
+			// gets recreated, so its own Lstat info is preserved instead.
+			linkInfo, err := opt.sourceFS.Lstat(origSrc)
+			if err != nil {
+				return err
+			}
+
+			if err := applyMetadata(origSrc, dst, linkInfo, false, opt); err != nil {
+				return err
+			}
+
+			if opt.preserve.has(PreserveTimes) {
+				if err := applyTimes(dst, linkInfo, false); err != nil {
+					return err
+				}
+			}
+		}
+
+		if opt.checksum != nil {
+			if err := opt.checksum.addSymlink(".", srcInfo.Mode(), src); err != nil {
+				return err
+			}
+		}
+
+		if opt.progress != nil {
+			opt.progress(".", 0)
+		}
+	case srcInfo.IsDir():
+		if err := copyTree(ctx, src, dst, opt); err != nil {
+			return err
+		}
+	default:
+		if err := copyFile(ctx, src, dst, ".", opt); err != nil {
+			return err
+		}
 	}
 
-	if srcInfo.IsDir() {
-		return copyTree(ctx, src, dst, opt)
+	if opt.checksum != nil {
+		return opt.checksum.finalize(".")
 	}
 
-	return copyFile(ctx, src, dst, opt)
+	return nil
 }
 
+// copyTree walks src in order, creating directories synchronously (a child
+// can only be created once its parent exists), while dispatching file and
+// symlink copies to a bounded worker pool. The first worker error cancels
+// gctx, which in-flight file copies observe through the existing contextio
+// wrappers; the walk itself does not check gctx and runs to completion
+// before that first error is returned.
 func copyTree(ctx context.Context, src, dst string, opt *options) error {
-	return filepath.Walk(
-		src, func(root string, info fs.FileInfo, err error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(opt.concurrency)
+
+	walkErr := vfs.Walk(
+		opt.sourceFS, src, func(root string, info fs.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
 
-			res, link, err := utils.ResolvePath(root)
+			res, link, err := utils.ResolvePath(opt.sourceFS, root)
 			if err != nil {
 				return err
 			}
@@ -93,8 +152,54 @@ func copyTree(ctx context.Context, src, dst string, opt *options) error {
 				return nil
 			}
 
-			if link {
-				info, err = os.Stat(res)
+			rel, err := filepath.Rel(src, root)
+			if err != nil {
+				return err
+			}
+
+			if info.IsDir() && opt.exclude != nil {
+				skip, err := opt.exclude.ExcludeDir(rel)
+				if err != nil {
+					return err
+				}
+
+				if skip {
+					return filepath.SkipDir
+				}
+			}
+
+			if info.IsDir() && opt.include != nil {
+				may, err := opt.include.MayContain(rel)
+				if err != nil {
+					return err
+				}
+
+				if !may {
+					return filepath.SkipDir
+				}
+			}
+
+			// A directory that survives the include/exclude pruning above
+			// is still always traversed and registered for bookkeeping
+			// (mkdir, checksum tree linkage, preserved metadata), even if
+			// it has no currently-known matching descendant itself (e.g.
+			// a "**" include pattern can match at any depth); only leaf
+			// entries (files, symlinks) are gated by passes.
+			if !info.IsDir() {
+				if ok, err := opt.passes(rel); err != nil {
+					return err
+				} else if !ok {
+					return nil
+				}
+			}
+
+			// A followed symlink's own Lstat info doesn't tell us whether
+			// it points to a file or a directory, or the target's size, so
+			// re-stat through it. A symlink kept as a link via noFollow
+			// must keep its own Lstat info instead, since that's what gets
+			// recreated (and retimed/re-xattr'd) on dst.
+			if link && !opt.noFollow {
+				info, err = opt.sourceFS.Stat(res)
 				if err != nil {
 					return err
 				}
@@ -104,33 +209,94 @@ func copyTree(ctx context.Context, src, dst string, opt *options) error {
 
 			switch {
 			case link && opt.noFollow:
-				return os.Symlink(res, subDst)
+				g.Go(func() error {
+					if err := opt.destFS.Symlink(res, subDst); err != nil {
+						return err
+					}
+
+					if opt.preserve != 0 && isOSFS(opt.sourceFS) && isOSFS(opt.destFS) {
+						if err := applyMetadata(root, subDst, info, false, opt); err != nil {
+							return err
+						}
+
+						if opt.preserve.has(PreserveTimes) {
+							if err := applyTimes(subDst, info, false); err != nil {
+								return err
+							}
+						}
+					}
+
+					if opt.checksum != nil {
+						if err := opt.checksum.addSymlink(rel, info.Mode(), res); err != nil {
+							return err
+						}
+					}
+
+					if opt.progress != nil {
+						opt.progress(rel, 0)
+					}
+
+					return nil
+				})
+
+				return nil
 			case info.IsDir():
-				return os.MkdirAll(subDst, info.Mode())
+				if err := opt.destFS.MkdirAll(subDst, info.Mode()); err != nil {
+					return err
+				}
+
+				if opt.checksum != nil {
+					opt.checksum.addDir(rel)
+				}
+
+				if opt.preserve != 0 && isOSFS(opt.sourceFS) && isOSFS(opt.destFS) {
+					if err := applyMetadata(root, subDst, info, true, opt); err != nil {
+						return err
+					}
+
+					if opt.preserve.has(PreserveTimes) {
+						opt.recordDirTime(subDst, info)
+					}
+				}
+
+				return nil
 			default:
-				if err := os.MkdirAll(path.Dir(subDst), info.Mode()); err != nil {
+				if err := opt.destFS.MkdirAll(path.Dir(subDst), info.Mode()); err != nil {
 					return err
 				}
 
-				return copyFile(ctx, root, subDst, opt)
+				g.Go(func() error { return copyFile(gctx, root, subDst, rel, opt) })
+
+				return nil
 			}
 		},
 	)
+	if walkErr != nil {
+		_ = g.Wait()
+
+		return walkErr
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	return opt.applyDirTimes()
 }
 
-func copyFile(ctx context.Context, src, dst string, opt *options) (err error) {
-	stat, err := os.Stat(src)
+func copyFile(ctx context.Context, src, dst, rel string, opt *options) (err error) {
+	stat, err := opt.sourceFS.Stat(src)
 	if err != nil {
 		return err
 	}
 
-	srcF, err := os.Open(src)
+	srcF, err := opt.sourceFS.Open(src)
 	if err != nil {
 		return err
 	}
 	defer srcF.Close()
 
-	dstF, err := os.OpenFile(
+	dstF, err := opt.destFS.OpenFile(
 		dst,
 		os.O_RDWR|os.O_CREATE|os.O_TRUNC,
 		stat.Mode().Perm(),
@@ -140,10 +306,78 @@ func copyFile(ctx context.Context, src, dst string, opt *options) (err error) {
 	}
 	defer dstF.Close()
 
-	return copyBytes(ctx, srcF, dstF, opt.bufSize)
+	var digest hash.Hash
+
+	if opt.checksum != nil {
+		if digest, err = newDigest(opt.checksum.algo); err != nil {
+			return err
+		}
+
+		if err := copyBytes(ctx, srcF, dstF, opt.bufSize, digest); err != nil {
+			return err
+		}
+	} else {
+		// fastCopy needs the whole file handed to the kernel as-is, so it is
+		// only attempted when no digest needs to be computed inline and both
+		// ends are backed by the real filesystem.
+		done, err := tryFastCopy(ctx, srcF, dstF, stat.Size(), opt)
+		if err != nil {
+			return err
+		}
+
+		if !done {
+			if err := copyBytes(ctx, srcF, dstF, opt.bufSize, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	if opt.progress != nil {
+		opt.progress(rel, stat.Size())
+	}
+
+	if opt.preserve != 0 && isOSFS(opt.sourceFS) && isOSFS(opt.destFS) {
+		if err := applyMetadata(src, dst, stat, true, opt); err != nil {
+			return err
+		}
+
+		if opt.preserve.has(PreserveTimes) {
+			if err := applyTimes(dst, stat, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	if opt.checksum == nil {
+		return nil
+	}
+
+	return opt.checksum.addFile(rel, stat.Mode(), stat.Size(), digest.Sum(nil))
 }
 
-func copyBytes(ctx context.Context, r io.Reader, w io.Writer, size int) error {
+// tryFastCopy attempts the platform fast path, which needs a concrete
+// *os.File on both ends for its ioctl/syscall-based copies. It reports
+// false, nil whenever either side isn't backed by the real filesystem, so
+// callers fall back to the buffered copy loop.
+func tryFastCopy(ctx context.Context, srcF vfs.File, dstF vfs.WriteFile, size int64, opt *options) (bool, error) {
+	srcOS, ok := srcF.(*os.File)
+	if !ok {
+		return false, nil
+	}
+
+	dstOS, ok := dstF.(*os.File)
+	if !ok {
+		return false, nil
+	}
+
+	return fastCopy(ctx, srcOS, dstOS, size, opt)
+}
+
+func copyBytes(ctx context.Context, r io.Reader, w io.Writer, size int, digest hash.Hash) error {
+	if digest != nil {
+		w = io.MultiWriter(w, digest)
+	}
+
 	src := contextio.Reader(ctx, r)
 	dst := contextio.Writer(ctx, w)
 	buf := make([]byte, size)
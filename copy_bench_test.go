@@ -0,0 +1,62 @@
+package copy
+
+import (
+	"os"
+	"path"
+	"strconv"
+	"testing"
+)
+
+// createBenchTree builds a tree with manySmall small files and a few large
+// files, used to compare serial and parallel copyTree throughput.
+func createBenchTree(b *testing.B, manySmall, large int, largeSize int) string {
+	b.Helper()
+
+	temp, err := os.MkdirTemp("", "")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for i := 0; i < manySmall; i++ {
+		p := path.Join(temp, "small-"+strconv.Itoa(i))
+		if err := os.WriteFile(p, testData, 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	bigData := make([]byte, largeSize)
+
+	for i := 0; i < large; i++ {
+		p := path.Join(temp, "large-"+strconv.Itoa(i))
+		if err := os.WriteFile(p, bigData, 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	return temp
+}
+
+func benchmarkCopyTree(b *testing.B, opts ...optFunc) {
+	src := createBenchTree(b, 500, 3, 8*1024*1024)
+	defer os.RemoveAll(src)
+
+	dst := path.Join(os.TempDir(), "bench-destination")
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := Copy(b.Context(), src, dst, append(opts, Force)...); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	os.RemoveAll(dst)
+}
+
+func BenchmarkCopyTreeSerial(b *testing.B) {
+	benchmarkCopyTree(b, WithConcurrency(1))
+}
+
+func BenchmarkCopyTreeParallel(b *testing.B) {
+	benchmarkCopyTree(b)
+}
@@ -0,0 +1,44 @@
+//go:build darwin
+
+package copy
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// fastCopy attempts a clonefile(2) copy-on-write clone. It reports
+// done=false when the filesystem does not support clonefile, so the caller
+// falls back to the buffered loop.
+func fastCopy(_ context.Context, src, dst *os.File, _ int64, opt *options) (bool, error) {
+	if opt.forceUserspace || opt.noReflink {
+		return false, nil
+	}
+
+	// clonefile(2) requires the destination path to not exist yet, so clone
+	// into a temp sibling and rename it over dst on success. Removing dst
+	// up front and cloning straight to its path would leave an unlinked,
+	// path-less dst file behind whenever Clonefileat then failed (e.g.
+	// EXDEV, the common case when src/dst aren't on the same APFS volume).
+	tmp := dst.Name() + ".clonefile.tmp"
+
+	err := unix.Clonefileat(unix.AT_FDCWD, src.Name(), unix.AT_FDCWD, tmp, 0)
+	if err != nil {
+		if errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EXDEV) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if err := os.Rename(tmp, dst.Name()); err != nil {
+		os.Remove(tmp)
+
+		return false, err
+	}
+
+	return true, nil
+}
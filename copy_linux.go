@@ -0,0 +1,71 @@
+//go:build linux
+
+package copy
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// fastCopy attempts, in order, a FICLONE reflink (Btrfs/XFS/ZFS) and an
+// in-kernel copy_file_range(2) copy. It reports done=false when neither is
+// supported by the underlying filesystem, so the caller falls back to the
+// buffered loop. copy_file_range cannot be interrupted mid-call, so the
+// copy is split into opt.bufSize chunks with ctx checked between them to
+// preserve the existing cancellation semantics.
+func fastCopy(ctx context.Context, src, dst *os.File, size int64, opt *options) (bool, error) {
+	if opt.forceUserspace {
+		return false, nil
+	}
+
+	if !opt.noReflink {
+		if err := unix.IoctlFileClone(int(dst.Fd()), int(src.Fd())); err == nil {
+			return true, nil
+		} else if !errors.Is(err, unix.EXDEV) && !errors.Is(err, unix.EOPNOTSUPP) &&
+			!errors.Is(err, unix.ENOTTY) && !errors.Is(err, unix.EINVAL) {
+			return false, err
+		}
+	}
+
+	return copyFileRange(ctx, src, dst, size, opt.bufSize)
+}
+
+// copyFileRange copies size bytes from src to dst via copy_file_range(2) in
+// chunks of at most chunkSize, checking ctx between chunks. It reports
+// done=false (with no error) if the syscall is not supported, so the
+// buffered loop can take over.
+func copyFileRange(ctx context.Context, src, dst *os.File, size int64, chunkSize int) (bool, error) {
+	var off int64
+
+	for off < size {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		want := int64(chunkSize)
+		if remaining := size - off; remaining < want {
+			want = remaining
+		}
+
+		n, err := unix.CopyFileRange(int(src.Fd()), nil, int(dst.Fd()), nil, int(want), 0)
+		if err != nil {
+			if errors.Is(err, unix.EXDEV) || errors.Is(err, unix.ENOSYS) ||
+				errors.Is(err, unix.EOPNOTSUPP) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		if n == 0 {
+			break
+		}
+
+		off += int64(n)
+	}
+
+	return true, nil
+}
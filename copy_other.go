@@ -0,0 +1,14 @@
+//go:build !linux && !darwin && !windows
+
+package copy
+
+import (
+	"context"
+	"os"
+)
+
+// fastCopy has no in-kernel fast path on this platform; it always reports
+// done=false so the caller uses the buffered loop.
+func fastCopy(_ context.Context, _, _ *os.File, _ int64, _ *options) (bool, error) {
+	return false, nil
+}
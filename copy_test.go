@@ -134,7 +134,7 @@ func TestCopy(t *testing.T) {
 
 				for _, s := range symlinks {
 					sp := strings.Split(s, ":")
-					_, link, err := utils.ResolvePath(path.Join(dst, sp[1]))
+					_, link, err := utils.ResolvePath(OSFS{}, path.Join(dst, sp[1]))
 					if err != nil {
 						return err
 					}
@@ -156,7 +156,7 @@ func TestCopy(t *testing.T) {
 			func() context.Context { return t.Context() },
 			func() error { return nil },
 			func(_ error) error {
-				_, link, err := utils.ResolvePath(path.Join(dst, "file3"))
+				_, link, err := utils.ResolvePath(OSFS{}, path.Join(dst, "file3"))
 				if err != nil {
 					return err
 				}
@@ -225,7 +225,7 @@ func TestCopy(t *testing.T) {
 						continue
 					}
 
-					res, link, err := utils.ResolvePath(path.Join(dst, sp[1]))
+					res, link, err := utils.ResolvePath(OSFS{}, path.Join(dst, sp[1]))
 					if err != nil {
 						return err
 					}
@@ -234,7 +234,7 @@ func TestCopy(t *testing.T) {
 						return fmt.Errorf("%s: is not a symlink", path.Join(dst, sp[1]))
 					}
 
-					p, _, err := utils.ResolvePath(path.Join(src, sp[1]))
+					p, _, err := utils.ResolvePath(OSFS{}, path.Join(src, sp[1]))
 					if err != nil {
 						return err
 					}
@@ -251,8 +251,202 @@ func TestCopy(t *testing.T) {
 			},
 			func() error { return nil },
 		},
+		{
+			"TreeCopyIncludePatterns",
+			src,
+			dst,
+			[]optFunc{WithIncludePatterns([]string{"**/file2"})},
+			func() context.Context { return t.Context() },
+			func() error { return nil },
+			func(cErr error) error {
+				if cErr != nil {
+					return cErr
+				}
+
+				for _, f := range folders {
+					if _, err := os.Stat(path.Join(dst, f)); errors.Is(err, fs.ErrNotExist) {
+						return fmt.Errorf("%s: expected directory to be traversed regardless of include patterns: %w", f, err)
+					}
+				}
+
+				if _, err := os.Stat(path.Join(dst, "folder1/folder2/file2")); err != nil {
+					return err
+				}
+
+				for _, f := range []string{"file1", "folder1/folder2/folder3/file3"} {
+					if _, err := os.Stat(path.Join(dst, f)); !errors.Is(err, fs.ErrNotExist) {
+						return fmt.Errorf("%s: expected to be excluded by include patterns", f)
+					}
+				}
+
+				return nil
+			},
+			func() error { return nil },
+		},
+		{
+			"TreeCopyIncludePatternsPrunesDirs",
+			src,
+			dst,
+			[]optFunc{WithIncludePatterns([]string{"folder1/folder2/file2"})},
+			func() context.Context { return t.Context() },
+			func() error { return nil },
+			func(cErr error) error {
+				if cErr != nil {
+					return cErr
+				}
+
+				for _, f := range []string{"folder1", "folder1/folder2"} {
+					if _, err := os.Stat(path.Join(dst, f)); errors.Is(err, fs.ErrNotExist) {
+						return fmt.Errorf("%s: expected directory to be traversed: %w", f, err)
+					}
+				}
+
+				if _, err := os.Stat(path.Join(dst, "folder1/folder2/file2")); err != nil {
+					return err
+				}
+
+				for _, f := range []string{"folder1/folder2_1", "folder1/folder2/folder3"} {
+					if _, err := os.Stat(path.Join(dst, f)); !errors.Is(err, fs.ErrNotExist) {
+						return fmt.Errorf(
+							"%s: expected to be pruned, no include pattern can match under it", f,
+						)
+					}
+				}
+
+				return nil
+			},
+			func() error { return nil },
+		},
+		{
+			"TreeCopyExcludePatterns",
+			src,
+			dst,
+			[]optFunc{WithExcludePatterns([]string{"folder1/folder2/folder3"})},
+			func() context.Context { return t.Context() },
+			func() error { return nil },
+			func(cErr error) error {
+				if cErr != nil {
+					return cErr
+				}
+
+				if _, err := os.Stat(path.Join(dst, "folder1/folder2/file2")); err != nil {
+					return err
+				}
+
+				if _, err := os.Stat(
+					path.Join(dst, "folder1/folder2/folder3"),
+				); !errors.Is(err, fs.ErrNotExist) {
+					return fmt.Errorf("folder1/folder2/folder3: expected to be excluded")
+				}
+
+				return nil
+			},
+			func() error { return nil },
+		},
+		{
+			"TreeCopyConcurrency",
+			src,
+			dst,
+			[]optFunc{WithConcurrency(2)},
+			func() context.Context { return t.Context() },
+			func() error { return nil },
+			func(cErr error) error {
+				if cErr != nil {
+					return cErr
+				}
+
+				for _, f := range append(folders, files...) {
+					if _, err := os.Stat(path.Join(dst, f)); errors.Is(err, fs.ErrNotExist) {
+						return err
+					}
+				}
+
+				return nil
+			},
+			func() error { return nil },
+		},
+		{
+			"FileCopyForceUserspace",
+			path.Join(src, "file1"),
+			dst + "/",
+			[]optFunc{WithForceUserspace},
+			func() context.Context { return t.Context() },
+			func() error { return nil },
+			func(cErr error) error {
+				if cErr != nil {
+					return cErr
+				}
+
+				data, err := os.ReadFile(path.Join(dst, "file1"))
+				if err != nil {
+					return err
+				}
+
+				if !bytes.Equal(testData, data) {
+					return fmt.Errorf(
+						"byte slices are not equal: want: %v; got: %v", testData, data,
+					)
+				}
+
+				return nil
+			},
+			func() error { return nil },
+		},
+		{
+			"FileCopyNoReflink",
+			path.Join(src, "file1"),
+			dst + "/",
+			[]optFunc{WithNoReflink},
+			func() context.Context { return t.Context() },
+			func() error { return nil },
+			func(cErr error) error {
+				if cErr != nil {
+					return cErr
+				}
+
+				data, err := os.ReadFile(path.Join(dst, "file1"))
+				if err != nil {
+					return err
+				}
+
+				if !bytes.Equal(testData, data) {
+					return fmt.Errorf(
+						"byte slices are not equal: want: %v; got: %v", testData, data,
+					)
+				}
+
+				return nil
+			},
+			func() error { return nil },
+		},
 	}
 
+	var progressCalls []string
+
+	tests = append(tests, testCase{
+		"FileCopyProgress",
+		path.Join(src, "file1"),
+		dst + "/",
+		[]optFunc{WithProgress(func(p string, n int64) {
+			progressCalls = append(progressCalls, fmt.Sprintf("%s:%d", p, n))
+		})},
+		func() context.Context { return t.Context() },
+		func() error { return nil },
+		func(cErr error) error {
+			if cErr != nil {
+				return cErr
+			}
+
+			want := fmt.Sprintf(".:%d", len(testData))
+			if len(progressCalls) != 1 || progressCalls[0] != want {
+				return fmt.Errorf("progress: want one call %q; got: %v", want, progressCalls)
+			}
+
+			return nil
+		},
+		func() error { return nil },
+	})
+
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			if err := tc.preFunc(); err != nil {
@@ -0,0 +1,49 @@
+//go:build windows
+
+package copy
+
+import (
+	"context"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	modkernel32     = windows.NewLazySystemDLL("kernel32.dll")
+	procCopyFileExW = modkernel32.NewProc("CopyFileExW")
+)
+
+// fastCopy delegates to the Win32 CopyFileExW API, letting the OS perform
+// the copy in-kernel. It reports done=false on any failure so the caller
+// falls back to the buffered loop.
+func fastCopy(_ context.Context, src, dst *os.File, _ int64, opt *options) (bool, error) {
+	if opt.forceUserspace {
+		return false, nil
+	}
+
+	srcPtr, err := windows.UTF16PtrFromString(src.Name())
+	if err != nil {
+		return false, err
+	}
+
+	dstPtr, err := windows.UTF16PtrFromString(dst.Name())
+	if err != nil {
+		return false, err
+	}
+
+	ok, _, _ := procCopyFileExW.Call(
+		uintptr(unsafe.Pointer(srcPtr)),
+		uintptr(unsafe.Pointer(dstPtr)),
+		0,
+		0,
+		0,
+		0,
+	)
+	if ok == 0 {
+		return false, nil
+	}
+
+	return true, nil
+}
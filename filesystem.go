@@ -0,0 +1,35 @@
+package copy
+
+import (
+	"io/fs"
+
+	"github.com/emar-kar/copy/v2/internal/vfs"
+)
+
+// FS abstracts the filesystem calls [Copy] performs, letting src/dst be
+// something other than the real disk: an embedded fs.FS, an in-memory
+// [MemFS] tree, or another in-memory tree entirely. See [WithSourceFS] and
+// [WithDestFS].
+type FS = vfs.FS
+
+// OSFS is the default FS for both source and destination, backed by the
+// real filesystem via the os package.
+type OSFS = vfs.OSFS
+
+// MemFS is a minimal in-memory FS, primarily useful for tests.
+type MemFS = vfs.MemFS
+
+// NewMemFS returns an empty [MemFS].
+func NewMemFS() *MemFS { return vfs.NewMemFS() }
+
+// FSFromIOFS adapts a read-only fs.FS (an embed.FS, a zip.Reader, ...) as
+// a source FS.
+func FSFromIOFS(fsys fs.FS) FS { return vfs.FSFromIOFS(fsys) }
+
+// isOSFS reports whether fsys is backed by the real filesystem. Fast
+// paths and metadata preservation only make sense against the real disk.
+func isOSFS(fsys FS) bool {
+	_, ok := fsys.(OSFS)
+
+	return ok
+}
@@ -0,0 +1,78 @@
+package copy
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestCopyToMemFS(t *testing.T) {
+	src, err := createSourceTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+
+	mem := NewMemFS()
+
+	if err := Copy(t.Context(), src, "dst", WithDestFS(mem)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range files {
+		rf, err := mem.Open(path.Join("dst", f))
+		if err != nil {
+			t.Fatalf("%s: %s", f, err)
+		}
+
+		data, err := io.ReadAll(rf)
+		rf.Close()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(testData, data) {
+			t.Errorf("%s: want: %v; got: %v", f, testData, data)
+		}
+	}
+}
+
+func TestCopyFromMemFS(t *testing.T) {
+	mem := NewMemFS()
+
+	if err := mem.MkdirAll("src/sub", 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := mem.OpenFile("src/sub/file", os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := wf.Write(testData); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := wf.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := path.Join(os.TempDir(), "destination-from-memfs")
+	defer os.RemoveAll(dst)
+
+	if err := Copy(t.Context(), "src", dst, WithSourceFS(mem)); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path.Join(dst, "sub", "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(testData, data) {
+		t.Errorf("want: %v; got: %v", testData, data)
+	}
+}
@@ -0,0 +1,216 @@
+// Package patternmatch implements .dockerignore-style include/exclude
+// pattern matching, following the conventions used by moby/patternmatcher:
+// "*", "?", "**" (recursive) and "[]" character classes within a path
+// segment, plus a leading "!" to negate a pattern.
+package patternmatch
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+type pattern struct {
+	clean  string
+	negate bool
+}
+
+// Matcher matches relative paths against a compiled set of patterns.
+// The last pattern to match a given path wins, mirroring .gitignore
+// and .dockerignore semantics.
+type Matcher struct {
+	patterns []pattern
+	hasNeg   bool
+}
+
+// New compiles patterns into a [Matcher]. Blank lines and lines starting
+// with "#" are ignored.
+func New(patterns []string) (*Matcher, error) {
+	m := &Matcher{}
+
+	for _, p := range patterns {
+		p = strings.TrimSpace(filepath.ToSlash(p))
+		if p == "" || strings.HasPrefix(p, "#") {
+			continue
+		}
+
+		var negate bool
+		if strings.HasPrefix(p, "!") {
+			negate = true
+			p = p[1:]
+		}
+
+		p = filepath.ToSlash(filepath.Clean(p))
+
+		if _, err := filepath.Match(p, ""); err != nil {
+			return nil, err
+		}
+
+		m.patterns = append(m.patterns, pattern{p, negate})
+		if negate {
+			m.hasNeg = true
+		}
+	}
+
+	return m, nil
+}
+
+// Match reports whether rel, a slash-separated path relative to the tree
+// root, is matched by the compiled patterns. A pattern also matches every
+// descendant of a directory it matches.
+func (m *Matcher) Match(rel string) (bool, error) {
+	rel = filepath.ToSlash(filepath.Clean(rel))
+	if rel == "." {
+		return false, nil
+	}
+
+	parts := strings.Split(rel, "/")
+	var matched bool
+
+	for _, pat := range m.patterns {
+		for i := 1; i <= len(parts); i++ {
+			ok, err := match(pat.clean, strings.Join(parts[:i], "/"))
+			if err != nil {
+				return false, err
+			}
+
+			if ok {
+				matched = !pat.negate
+				break
+			}
+		}
+	}
+
+	return matched, nil
+}
+
+// ExcludeDir reports whether the directory rel can be skipped entirely,
+// i.e. it is excluded and no negation pattern could re-include any of its
+// descendants. Callers use this to short-circuit a tree walk.
+func (m *Matcher) ExcludeDir(rel string) (bool, error) {
+	excluded, err := m.Match(rel)
+	if err != nil || !excluded {
+		return false, err
+	}
+
+	if !m.hasNeg {
+		return true, nil
+	}
+
+	rel = filepath.ToSlash(filepath.Clean(rel))
+
+	for _, pat := range m.patterns {
+		if !pat.negate {
+			continue
+		}
+
+		ok, err := potentialMatch(pat.clean, rel)
+		if err != nil {
+			return false, err
+		}
+
+		if ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// MayContain reports whether the directory rel could possibly contain a
+// path matched by one of the compiled patterns, treating rel's unresolved
+// segments as wildcards. Callers use this to prune a directory from an
+// include-only walk once it's provable that none of its descendants can
+// match, the same potential-match analysis [ExcludeDir] uses for
+// negations.
+func (m *Matcher) MayContain(rel string) (bool, error) {
+	rel = filepath.ToSlash(filepath.Clean(rel))
+	if rel == "." {
+		return true, nil
+	}
+
+	// A directory matched itself (or through an ancestor) has every
+	// descendant matched as well, see [Matcher.Match].
+	if ok, err := m.Match(rel); err != nil || ok {
+		return ok, err
+	}
+
+	for _, pat := range m.patterns {
+		if pat.negate {
+			continue
+		}
+
+		ok, err := potentialMatch(pat.clean, rel)
+		if err != nil {
+			return false, err
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// match reports whether pattern matches name exactly, segment by segment,
+// where "**" matches zero or more whole segments.
+func match(pattern, name string) (bool, error) {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pat, name []string) (bool, error) {
+	for len(pat) > 0 {
+		if pat[0] == "**" {
+			if len(pat) == 1 {
+				return true, nil
+			}
+
+			for i := 0; i <= len(name); i++ {
+				ok, err := matchSegments(pat[1:], name[i:])
+				if err != nil || ok {
+					return ok, err
+				}
+			}
+
+			return false, nil
+		}
+
+		if len(name) == 0 {
+			return false, nil
+		}
+
+		ok, err := filepath.Match(pat[0], name[0])
+		if err != nil || !ok {
+			return false, err
+		}
+
+		pat, name = pat[1:], name[1:]
+	}
+
+	return len(name) == 0, nil
+}
+
+// potentialMatch reports whether pattern could match some path under dir,
+// treating the segments of dir not yet covered by pattern as wildcards.
+func potentialMatch(pattern, dir string) (bool, error) {
+	pat, name := strings.Split(pattern, "/"), strings.Split(dir, "/")
+
+	for len(name) > 0 {
+		if len(pat) == 0 {
+			return false, nil
+		}
+
+		if pat[0] == "**" {
+			return true, nil
+		}
+
+		ok, err := filepath.Match(pat[0], name[0])
+		if err != nil || !ok {
+			return false, err
+		}
+
+		pat, name = pat[1:], name[1:]
+	}
+
+	return true, nil
+}
@@ -0,0 +1,105 @@
+package patternmatch
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		want     bool
+	}{
+		{"Simple", []string{"*.log"}, "debug.log", true},
+		{"NoMatch", []string{"*.log"}, "debug.txt", false},
+		{"DirPrefix", []string{"vendor"}, "vendor/pkg/file.go", true},
+		{"Recursive", []string{"**/*.tmp"}, "a/b/c.tmp", true},
+		{"Negate", []string{"*.log", "!debug.log"}, "debug.log", false},
+		{
+			"NegateNested",
+			[]string{"folder", "!folder/keep"},
+			"folder/keep/file",
+			false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := New(tc.patterns)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := m.Match(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.want {
+				t.Errorf("%s: want: %t; got: %t", tc.path, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExcludeDir(t *testing.T) {
+	m, err := New([]string{"folder", "!folder/keep"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	skip, err := m.ExcludeDir("folder")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if skip {
+		t.Error("folder: expected descent, negation could re-include a child")
+	}
+
+	m, err = New([]string{"folder"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	skip, err = m.ExcludeDir("folder")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !skip {
+		t.Error("folder: expected directory to be skipped, no negation present")
+	}
+}
+
+func TestMayContain(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		dir      string
+		want     bool
+	}{
+		{"ExactDir", []string{"folder/sub/file.txt"}, "folder", true},
+		{"Sibling", []string{"folder/sub/file.txt"}, "other", false},
+		{"Recursive", []string{"**/file.txt"}, "other", true},
+		{"Root", []string{"folder/file.txt"}, ".", true},
+		{"IgnoresNegation", []string{"folder/sub/file.txt", "!other"}, "other", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := New(tc.patterns)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := m.MayContain(tc.dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.want {
+				t.Errorf("%s: want: %t; got: %t", tc.dir, tc.want, got)
+			}
+		})
+	}
+}
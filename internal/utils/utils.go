@@ -3,11 +3,14 @@ package utils
 import (
 	"os"
 	"path/filepath"
+
+	"github.com/emar-kar/copy/v2/internal/vfs"
 )
 
-// ResolvePath resolves symlinks and relative paths.
-func ResolvePath(p string) (string, bool, error) {
-	info, err := os.Lstat(p)
+// ResolvePath resolves symlinks on fsys and reports whether p was one.
+// Relative paths are made absolute only when fsys is [vfs.OSFS].
+func ResolvePath(fsys vfs.FS, p string) (string, bool, error) {
+	info, err := fsys.Lstat(p)
 	if err != nil {
 		return "", false, err
 	}
@@ -17,12 +20,14 @@ func ResolvePath(p string) (string, bool, error) {
 	if info.Mode()&os.ModeSymlink == os.ModeSymlink {
 		isLink = true
 
-		if p, err = filepath.EvalSymlinks(p); err != nil {
+		if p, err = fsys.EvalSymlinks(p); err != nil {
 			return "", isLink, err
 		}
 	}
 
-	p, err = filepath.Abs(p)
+	if _, ok := fsys.(vfs.OSFS); ok {
+		p, err = filepath.Abs(p)
+	}
 
 	return p, isLink, err
 }
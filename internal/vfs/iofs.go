@@ -0,0 +1,54 @@
+package vfs
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// ioFS adapts a read-only fs.FS as a source FS. fs.FS has no concept of
+// symlinks or writing, so those calls either no-op or fail.
+type ioFS struct{ fsys fs.FS }
+
+// FSFromIOFS adapts fsys (an embed.FS, a zip.Reader, ...) as a read-only
+// source FS.
+func FSFromIOFS(fsys fs.FS) FS { return ioFS{fsys} }
+
+func (f ioFS) clean(name string) string {
+	name = filepath.ToSlash(name)
+	name = strings.TrimPrefix(name, "/")
+
+	if name == "" {
+		return "."
+	}
+
+	return name
+}
+
+func (f ioFS) Open(name string) (File, error) { return f.fsys.Open(f.clean(name)) }
+
+func (f ioFS) OpenFile(name string, _ int, _ fs.FileMode) (WriteFile, error) {
+	return nil, &fs.PathError{Op: "openfile", Path: name, Err: fs.ErrInvalid}
+}
+
+func (f ioFS) Stat(name string) (fs.FileInfo, error) { return fs.Stat(f.fsys, f.clean(name)) }
+
+func (f ioFS) Lstat(name string) (fs.FileInfo, error) { return f.Stat(name) }
+
+func (f ioFS) MkdirAll(path string, _ fs.FileMode) error {
+	return &fs.PathError{Op: "mkdirall", Path: path, Err: fs.ErrInvalid}
+}
+
+func (f ioFS) Symlink(_, newname string) error {
+	return &fs.PathError{Op: "symlink", Path: newname, Err: fs.ErrInvalid}
+}
+
+func (f ioFS) EvalSymlinks(path string) (string, error) { return f.clean(path), nil }
+
+func (f ioFS) RemoveAll(path string) error {
+	return &fs.PathError{Op: "removeall", Path: path, Err: fs.ErrInvalid}
+}
+
+func (f ioFS) Readlink(name string) (string, error) {
+	return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+}
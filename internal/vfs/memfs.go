@@ -0,0 +1,357 @@
+package vfs
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemFS is a minimal in-memory FS, primarily useful for tests: copying
+// into a MemFS needs no real temporary directories, and copying from one
+// goes through the same walk and buffered copy loop as copying from disk.
+// Platform fast paths and metadata preservation apply only to the real
+// filesystem and are skipped for a MemFS.
+type MemFS struct {
+	mu    sync.Mutex
+	nodes map[string]*memNode
+}
+
+type memNode struct {
+	dir        bool
+	mode       fs.FileMode
+	data       []byte
+	modTime    time.Time
+	linkTarget string
+}
+
+// NewMemFS returns an empty MemFS, containing only its root directory.
+func NewMemFS() *MemFS {
+	return &MemFS{
+		nodes: map[string]*memNode{
+			".": {dir: true, mode: fs.ModeDir | 0o755, modTime: time.Time{}},
+		},
+	}
+}
+
+func clean(name string) string {
+	name = path.Clean(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.TrimPrefix(name, "/")
+
+	if name == "" {
+		return "."
+	}
+
+	return name
+}
+
+func (m *MemFS) lookup(name string) (*memNode, error) {
+	node, ok := m.nodes[clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return node, nil
+}
+
+func (m *MemFS) Open(name string) (File, error) {
+	name = clean(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, err := m.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if node.dir {
+		return &memFile{name: name, node: node, entries: m.readDirLocked(name)}, nil
+	}
+
+	return &memFile{name: name, node: node, r: bytes.NewReader(node.data)}, nil
+}
+
+func (m *MemFS) readDirLocked(dir string) []fs.DirEntry {
+	prefix := dir + "/"
+	if dir == "." {
+		prefix = ""
+	}
+
+	seen := make(map[string]bool)
+
+	var out []fs.DirEntry
+
+	for p, n := range m.nodes {
+		if p == dir || !strings.HasPrefix(p, prefix) {
+			continue
+		}
+
+		rest := strings.TrimPrefix(p, prefix)
+		if strings.Contains(rest, "/") || seen[rest] {
+			continue
+		}
+
+		seen[rest] = true
+		out = append(out, memDirEntry{rest, n})
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Name() < out[j].Name() })
+
+	return out
+}
+
+func (m *MemFS) OpenFile(name string, flag int, perm fs.FileMode) (WriteFile, error) {
+	name = clean(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.nodes[path.Dir(name)]; !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	if flag&os.O_CREATE == 0 {
+		if _, err := m.lookup(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return &memWriteFile{name: name, fsys: m, mode: perm}, nil
+}
+
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, err := m.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	for node.linkTarget != "" {
+		node, err = m.lookup(node.linkTarget)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return memFileInfo{path.Base(clean(name)), node}, nil
+}
+
+func (m *MemFS) Lstat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, err := m.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return memFileInfo{path.Base(clean(name)), node}, nil
+}
+
+func (m *MemFS) MkdirAll(p string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p = clean(p)
+
+	var built string
+
+	for _, part := range strings.Split(p, "/") {
+		if built == "" {
+			built = part
+		} else {
+			built = built + "/" + part
+		}
+
+		if _, ok := m.nodes[built]; !ok {
+			m.nodes[built] = &memNode{dir: true, mode: fs.ModeDir | perm, modTime: time.Time{}}
+		}
+	}
+
+	return nil
+}
+
+func (m *MemFS) Symlink(oldname, newname string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	newname = clean(newname)
+
+	if _, ok := m.nodes[path.Dir(newname)]; !ok {
+		return &fs.PathError{Op: "symlink", Path: newname, Err: fs.ErrNotExist}
+	}
+
+	m.nodes[newname] = &memNode{
+		mode:       fs.ModeSymlink | 0o777,
+		linkTarget: clean(oldname),
+		modTime:    time.Time{},
+	}
+
+	return nil
+}
+
+func (m *MemFS) EvalSymlinks(p string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p = clean(p)
+
+	for {
+		node, err := m.lookup(p)
+		if err != nil {
+			return "", err
+		}
+
+		if node.linkTarget == "" {
+			return p, nil
+		}
+
+		p = node.linkTarget
+	}
+}
+
+func (m *MemFS) RemoveAll(p string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p = clean(p)
+	prefix := p + "/"
+
+	for k := range m.nodes {
+		if k == p || strings.HasPrefix(k, prefix) {
+			delete(m.nodes, k)
+		}
+	}
+
+	return nil
+}
+
+func (m *MemFS) Readlink(name string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, err := m.lookup(name)
+	if err != nil {
+		return "", err
+	}
+
+	if node.linkTarget == "" {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+	}
+
+	return node.linkTarget, nil
+}
+
+type memFileInfo struct {
+	name string
+	node *memNode
+}
+
+func (i memFileInfo) Name() string       { return i.name }
+func (i memFileInfo) Size() int64        { return int64(len(i.node.data)) }
+func (i memFileInfo) Mode() fs.FileMode  { return i.node.mode }
+func (i memFileInfo) ModTime() time.Time { return i.node.modTime }
+func (i memFileInfo) IsDir() bool        { return i.node.dir }
+func (i memFileInfo) Sys() any           { return i.node }
+
+type memDirEntry struct {
+	name string
+	node *memNode
+}
+
+func (e memDirEntry) Name() string               { return e.name }
+func (e memDirEntry) IsDir() bool                { return e.node.dir }
+func (e memDirEntry) Type() fs.FileMode          { return e.node.mode.Type() }
+func (e memDirEntry) Info() (fs.FileInfo, error) { return memFileInfo{e.name, e.node}, nil }
+
+// memFile implements File (and, for directories, fs.ReadDirFile) for
+// reading.
+type memFile struct {
+	name    string
+	node    *memNode
+	r       *bytes.Reader
+	entries []fs.DirEntry
+	pos     int
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return memFileInfo{f.name, f.node}, nil }
+
+func (f *memFile) Read(p []byte) (int, error) {
+	if f.node.dir {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrInvalid}
+	}
+
+	return f.r.Read(p)
+}
+
+func (f *memFile) Close() error { return nil }
+
+func (f *memFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !f.node.dir {
+		return nil, &fs.PathError{Op: "readdir", Path: f.name, Err: fs.ErrInvalid}
+	}
+
+	if n <= 0 {
+		out := f.entries[f.pos:]
+		f.pos = len(f.entries)
+
+		return out, nil
+	}
+
+	if f.pos >= len(f.entries) {
+		return nil, io.EOF
+	}
+
+	end := f.pos + n
+	if end > len(f.entries) {
+		end = len(f.entries)
+	}
+
+	out := f.entries[f.pos:end]
+	f.pos = end
+
+	return out, nil
+}
+
+// memWriteFile implements WriteFile, buffering writes until Close commits
+// them to the owning MemFS.
+type memWriteFile struct {
+	name string
+	fsys *MemFS
+	mode fs.FileMode
+	buf  bytes.Buffer
+}
+
+func (f *memWriteFile) Write(p []byte) (int, error) { return f.buf.Write(p) }
+
+func (f *memWriteFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrInvalid}
+}
+
+func (f *memWriteFile) Stat() (fs.FileInfo, error) {
+	return memFileInfo{
+		path.Base(f.name),
+		&memNode{mode: f.mode, data: f.buf.Bytes()},
+	}, nil
+}
+
+func (f *memWriteFile) Close() error {
+	f.fsys.mu.Lock()
+	defer f.fsys.mu.Unlock()
+
+	f.fsys.nodes[f.name] = &memNode{
+		mode:    f.mode,
+		data:    append([]byte(nil), f.buf.Bytes()...),
+		modTime: time.Time{},
+	}
+
+	return nil
+}
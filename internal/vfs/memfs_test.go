@@ -0,0 +1,112 @@
+package vfs
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+func TestMemFS(t *testing.T) {
+	m := NewMemFS()
+
+	if err := m.MkdirAll("dir/sub", 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := m.OpenFile("dir/sub/file", os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rf, err := m.Open("dir/sub/file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Close()
+
+	data, err := io.ReadAll(rf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "hello" {
+		t.Errorf("want: hello; got: %s", data)
+	}
+
+	if err := m.Symlink("dir/sub/file", "link"); err != nil {
+		t.Fatal(err)
+	}
+
+	target, err := m.Readlink("link")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if target != "dir/sub/file" {
+		t.Errorf("want: dir/sub/file; got: %s", target)
+	}
+
+	info, err := m.Stat("link")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.IsDir() || info.Size() != 5 {
+		t.Errorf("unexpected stat result for link target: %+v", info)
+	}
+}
+
+func TestMemFSWalk(t *testing.T) {
+	m := NewMemFS()
+
+	if err := m.MkdirAll("a/b", 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a/file1", "a/b/file2"} {
+		f, err := m.OpenFile(name, os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var seen []string
+
+	err := Walk(m, "a", func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		seen = append(seen, p)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"a", "a/b", "a/b/file2", "a/file1"}
+	if len(seen) != len(want) {
+		t.Fatalf("want: %v; got: %v", want, seen)
+	}
+
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("want: %v; got: %v", want, seen)
+			break
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Package vfs abstracts the filesystem calls [copy.Copy] performs behind
+// an afero-style interface, so a tree can be copied from or to something
+// other than the real disk: an embedded fs.FS, a MemFS tree, or another
+// in-memory tree entirely.
+package vfs
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// File is what FS.Open returns: a regular [fs.File], or, for a directory,
+// one that also implements [fs.ReadDirFile].
+type File = fs.File
+
+// WriteFile is a File opened for writing, as returned by FS.OpenFile.
+type WriteFile interface {
+	fs.File
+	io.Writer
+}
+
+// FS abstracts the os.* calls [copy.Copy] needs. [OSFS] is the default for
+// both source and destination; [MemFS] and [FSFromIOFS] provide
+// alternatives for tests and read-only sources.
+type FS interface {
+	Open(name string) (File, error)
+	OpenFile(name string, flag int, perm fs.FileMode) (WriteFile, error)
+	Stat(name string) (fs.FileInfo, error)
+	Lstat(name string) (fs.FileInfo, error)
+	MkdirAll(path string, perm fs.FileMode) error
+	Symlink(oldname, newname string) error
+	EvalSymlinks(path string) (string, error)
+	RemoveAll(path string) error
+	Readlink(name string) (string, error)
+}
+
+// OSFS implements FS on top of the real filesystem via the os package.
+type OSFS struct{}
+
+func (OSFS) Open(name string) (File, error) { return os.Open(name) }
+
+func (OSFS) OpenFile(name string, flag int, perm fs.FileMode) (WriteFile, error) {
+	return os.OpenFile(name, flag, perm)
+}
+
+func (OSFS) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+
+func (OSFS) Lstat(name string) (fs.FileInfo, error) { return os.Lstat(name) }
+
+func (OSFS) MkdirAll(path string, perm fs.FileMode) error { return os.MkdirAll(path, perm) }
+
+func (OSFS) Symlink(oldname, newname string) error { return os.Symlink(oldname, newname) }
+
+func (OSFS) EvalSymlinks(path string) (string, error) { return filepath.EvalSymlinks(path) }
+
+func (OSFS) RemoveAll(path string) error { return os.RemoveAll(path) }
+
+func (OSFS) Readlink(name string) (string, error) { return os.Readlink(name) }
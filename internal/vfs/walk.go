@@ -0,0 +1,88 @@
+package vfs
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// WalkFunc mirrors filepath.WalkFunc so callers migrating from
+// filepath.Walk need no changes beyond passing an FS.
+type WalkFunc func(path string, info fs.FileInfo, err error) error
+
+// Walk walks the tree rooted at root on fsys in lexical order, calling fn
+// for each entry. It mirrors filepath.Walk's contract, including returning
+// filepath.SkipDir from fn to skip a directory's children.
+func Walk(fsys FS, root string, fn WalkFunc) error {
+	info, err := fsys.Lstat(root)
+	if err != nil {
+		return fn(root, nil, err)
+	}
+
+	return walk(fsys, root, info, fn)
+}
+
+func walk(fsys FS, name string, info fs.FileInfo, fn WalkFunc) error {
+	if err := fn(name, info, nil); err != nil {
+		if info.IsDir() && err == filepath.SkipDir {
+			return nil
+		}
+
+		return err
+	}
+
+	if !info.IsDir() {
+		return nil
+	}
+
+	entries, err := readDir(fsys, name)
+	if err != nil {
+		return fn(name, info, err)
+	}
+
+	for _, e := range entries {
+		child := path.Join(name, e.Name())
+
+		childInfo, err := fsys.Lstat(child)
+		if err != nil {
+			if err := fn(child, childInfo, err); err != nil && err != filepath.SkipDir {
+				return err
+			}
+
+			continue
+		}
+
+		if err := walk(fsys, child, childInfo, fn); err != nil {
+			if err == filepath.SkipDir {
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readDir(fsys FS, name string) ([]fs.DirEntry, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rd, ok := f.(fs.ReadDirFile)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	entries, err := rd.ReadDir(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	return entries, nil
+}
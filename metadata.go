@@ -0,0 +1,125 @@
+package copy
+
+import (
+	"io/fs"
+	"os"
+	"sort"
+	"time"
+)
+
+// PreserveOpts is a bitmask selecting which metadata [WithPreserve] copies
+// from src to dst alongside file content.
+type PreserveOpts uint8
+
+const (
+	PreserveMode PreserveOpts = 1 << iota
+	PreserveOwner
+	PreserveTimes
+	PreserveXattrs
+	PreserveACLs
+
+	// PreserveStrict turns a failure to preserve owner, xattrs or ACLs
+	// (e.g. EPERM when not running as root) into a hard error instead of
+	// the default of silently skipping it.
+	PreserveStrict
+
+	PreserveAll = PreserveMode | PreserveOwner | PreserveTimes | PreserveXattrs | PreserveACLs
+)
+
+func (p PreserveOpts) has(flag PreserveOpts) bool { return p&flag != 0 }
+
+// dirTime is a directory's source timestamps, deferred until every child
+// has been written so the copy into it doesn't bump mtime back up.
+type dirTime struct {
+	path         string
+	atime, mtime time.Time
+}
+
+// recordDirTime queues dst's timestamps to be applied once the whole tree
+// has been copied.
+func (o *options) recordDirTime(dst string, info fs.FileInfo) {
+	atime, mtime := statTimes(info)
+	o.dirTimes = append(o.dirTimes, dirTime{dst, atime, mtime})
+}
+
+// applyDirTimes re-applies every queued directory's timestamps, deepest
+// path first, so a parent's mtime is fixed only after all of its children
+// have already been written and fixed.
+func (o *options) applyDirTimes() error {
+	times := o.dirTimes
+	sort.Slice(times, func(i, j int) bool { return len(times[i].path) > len(times[j].path) })
+
+	for _, t := range times {
+		if err := os.Chtimes(t.path, t.atime, t.mtime); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyMetadata preserves mode, ownership and xattr/ACL metadata from info
+// (src's FileInfo) onto dst. follow must be false for a symlink created via
+// [WithNoFollow]: its mode is then left as is, and owner/xattrs are applied
+// to the link itself rather than its target. Timestamps are handled separately, by applyTimes and
+// recordDirTime/applyDirTimes, since writing into a directory changes its
+// mtime.
+func applyMetadata(src, dst string, info fs.FileInfo, follow bool, opt *options) error {
+	if opt.preserve == 0 {
+		return nil
+	}
+
+	strict := opt.preserve.has(PreserveStrict)
+
+	if opt.preserve.has(PreserveMode) && follow {
+		if err := os.Chmod(dst, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+
+	if opt.preserve.has(PreserveOwner) {
+		if err := applyOwner(dst, info, follow, strict); err != nil {
+			return err
+		}
+	}
+
+	if opt.preserve.has(PreserveXattrs) {
+		if err := copyXattrs(src, dst, follow, false, strict); err != nil {
+			return err
+		}
+	}
+
+	if opt.preserve.has(PreserveACLs) {
+		if err := copyXattrs(src, dst, follow, true, strict); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyTimes preserves info's atime/mtime onto dst. For a symlink created
+// via [WithNoFollow] (follow=false) it uses lchtimes so the link itself is
+// retimed without dereferencing dst.
+func applyTimes(dst string, info fs.FileInfo, follow bool) error {
+	atime, mtime := statTimes(info)
+	if follow {
+		return os.Chtimes(dst, atime, mtime)
+	}
+
+	return lchtimes(dst, atime, mtime)
+}
+
+// ignorePermError swallows a permission error unless strict is set, used
+// for metadata (owner, xattrs, ACLs) that a non-root caller can't set.
+func ignorePermError(err error, strict bool) error {
+	if err == nil || strict {
+		return err
+	}
+
+	if os.IsPermission(err) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,84 @@
+//go:build darwin
+
+package copy
+
+import (
+	"io/fs"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// statTimes extracts atime/mtime from info's underlying [syscall.Stat_t].
+// Darwin names these fields Atimespec/Mtimespec.
+func statTimes(info fs.FileInfo) (atime, mtime time.Time) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return info.ModTime(), info.ModTime()
+	}
+
+	return time.Unix(stat.Atimespec.Sec, stat.Atimespec.Nsec),
+		time.Unix(stat.Mtimespec.Sec, stat.Mtimespec.Nsec)
+}
+
+// copyXattrs copies extended attributes from src to dst. Darwin stores
+// ACLs outside the xattr namespace (via acl_get_file/acl_set_file, not
+// exposed through golang.org/x/sys/unix), so PreserveACLs is a no-op here.
+// follow must be false for a symlink created via [WithNoFollow], so the
+// link-local Llistxattr/Lgetxattr/Lsetxattr variants are used instead of
+// dereferencing it. A permission error is swallowed unless strict is set.
+func copyXattrs(src, dst string, follow, acl, strict bool) error {
+	if acl {
+		return nil
+	}
+
+	listxattr, getxattr, setxattr := unix.Listxattr, unix.Getxattr, unix.Setxattr
+	if !follow {
+		listxattr, getxattr, setxattr = unix.Llistxattr, unix.Lgetxattr, unix.Lsetxattr
+	}
+
+	size, err := listxattr(src, nil)
+	if err != nil {
+		return ignorePermError(err, strict)
+	}
+
+	if size == 0 {
+		return nil
+	}
+
+	names := make([]byte, size)
+
+	if _, err := listxattr(src, names); err != nil {
+		return ignorePermError(err, strict)
+	}
+
+	for _, name := range splitXattrNames(names) {
+		vSize, err := getxattr(src, name, nil)
+		if err != nil {
+			if err := ignorePermError(err, strict); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		value := make([]byte, vSize)
+
+		if _, err := getxattr(src, name, value); err != nil {
+			if err := ignorePermError(err, strict); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := setxattr(dst, name, value, 0); err != nil {
+			if err := ignorePermError(err, strict); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+//go:build linux
+
+package copy
+
+import (
+	"io/fs"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// statTimes extracts atime/mtime from info's underlying [syscall.Stat_t].
+// Linux names these fields Atim/Mtim.
+func statTimes(info fs.FileInfo) (atime, mtime time.Time) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return info.ModTime(), info.ModTime()
+	}
+
+	return time.Unix(stat.Atim.Sec, stat.Atim.Nsec), time.Unix(stat.Mtim.Sec, stat.Mtim.Nsec)
+}
+
+// aclXattrs are the xattr names under which Linux stores POSIX ACLs.
+var aclXattrs = []string{"system.posix_acl_access", "system.posix_acl_default"}
+
+// copyXattrs copies extended attributes from src to dst. When acl is true,
+// only the POSIX ACL xattrs are considered; otherwise every xattr except
+// those is copied. follow must be false for a symlink created via
+// [WithNoFollow], so the link-local Llistxattr/Lgetxattr/Lsetxattr variants
+// are used instead of dereferencing it. A permission error is swallowed
+// unless strict is set.
+func copyXattrs(src, dst string, follow, acl, strict bool) error {
+	listxattr, getxattr, setxattr := unix.Listxattr, unix.Getxattr, unix.Setxattr
+	if !follow {
+		listxattr, getxattr, setxattr = unix.Llistxattr, unix.Lgetxattr, unix.Lsetxattr
+	}
+
+	size, err := listxattr(src, nil)
+	if err != nil {
+		return ignorePermError(err, strict)
+	}
+
+	if size == 0 {
+		return nil
+	}
+
+	names := make([]byte, size)
+
+	if _, err := listxattr(src, names); err != nil {
+		return ignorePermError(err, strict)
+	}
+
+	for _, name := range splitXattrNames(names) {
+		if isACLXattr(name) != acl {
+			continue
+		}
+
+		vSize, err := getxattr(src, name, nil)
+		if err != nil {
+			if err := ignorePermError(err, strict); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		value := make([]byte, vSize)
+
+		if _, err := getxattr(src, name, value); err != nil {
+			if err := ignorePermError(err, strict); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := setxattr(dst, name, value, 0); err != nil {
+			if err := ignorePermError(err, strict); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func isACLXattr(name string) bool {
+	for _, a := range aclXattrs {
+		if name == a {
+			return true
+		}
+	}
+
+	return false
+}
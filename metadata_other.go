@@ -0,0 +1,21 @@
+//go:build !linux && !darwin && !windows
+
+package copy
+
+import (
+	"io/fs"
+	"time"
+)
+
+// statTimes falls back to ModTime for both atime and mtime on platforms
+// without a known way to extract atime from [fs.FileInfo].
+func statTimes(info fs.FileInfo) (atime, mtime time.Time) { return info.ModTime(), info.ModTime() }
+
+// applyOwner is unimplemented on this platform.
+func applyOwner(_ string, _ fs.FileInfo, _, _ bool) error { return nil }
+
+// lchtimes is unimplemented on this platform.
+func lchtimes(_ string, _, _ time.Time) error { return nil }
+
+// copyXattrs is unimplemented on this platform.
+func copyXattrs(_, _ string, _, _, _ bool) error { return nil }
@@ -0,0 +1,145 @@
+package copy
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestCopyPreserve(t *testing.T) {
+	src, err := createSourceTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+
+	dst := path.Join(os.TempDir(), "destination-preserve")
+	defer os.RemoveAll(dst)
+
+	srcFile := path.Join(src, "file1")
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := os.Chtimes(srcFile, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Copy(
+		t.Context(), src, dst, WithPreserve(PreserveMode|PreserveTimes),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	dstFile := path.Join(dst, "file1")
+
+	srcInfo, err := os.Stat(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dstInfo, err := os.Stat(dstFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dstInfo.Mode().Perm() != srcInfo.Mode().Perm() {
+		t.Errorf("mode: want %v; got %v", srcInfo.Mode().Perm(), dstInfo.Mode().Perm())
+	}
+
+	if !dstInfo.ModTime().Equal(mtime) {
+		t.Errorf("mtime: want %v; got %v", mtime, dstInfo.ModTime())
+	}
+
+	data, err := os.ReadFile(dstFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(testData, data) {
+		t.Errorf("content: want %v; got %v", testData, data)
+	}
+}
+
+// TestCopyPreserveDirTimes checks the riskiest part of WithPreserve: a
+// directory's mtime is bumped by writing its children, so it must be
+// restored in a post-order pass after the whole tree has been copied.
+func TestCopyPreserveDirTimes(t *testing.T) {
+	src, err := createSourceTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+
+	dst := path.Join(os.TempDir(), "destination-preserve-dirtimes")
+	defer os.RemoveAll(dst)
+
+	srcDir := path.Join(src, "folder1/folder2")
+	mtime := time.Date(2019, 6, 15, 8, 0, 0, 0, time.UTC)
+
+	if err := os.Chtimes(srcDir, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Copy(t.Context(), src, dst, WithPreserve(PreserveTimes)); err != nil {
+		t.Fatal(err)
+	}
+
+	dstInfo, err := os.Stat(path.Join(dst, "folder1/folder2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !dstInfo.ModTime().Equal(mtime) {
+		t.Errorf(
+			"mtime: want %v; got %v (child writes must not leave it stale)",
+			mtime, dstInfo.ModTime(),
+		)
+	}
+}
+
+// TestCopyPreserveSymlinkTimes checks that a symlink created via
+// [WithNoFollow] is retimed itself, without dereferencing it.
+func TestCopyPreserveSymlinkTimes(t *testing.T) {
+	src, err := createSourceTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+
+	dst := path.Join(os.TempDir(), "destination-preserve-symlinktimes")
+	defer os.RemoveAll(dst)
+
+	srcLink := path.Join(src, "file3")
+	linkMtime := time.Date(2018, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	if err := lchtimes(srcLink, linkMtime, linkMtime); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Copy(
+		t.Context(), src, dst, WithNoFollow, WithPreserve(PreserveTimes),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	dstLink := path.Join(dst, "file3")
+
+	dstInfo, err := os.Lstat(dstLink)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !dstInfo.ModTime().Equal(linkMtime) {
+		t.Errorf("link mtime: want %v; got %v", linkMtime, dstInfo.ModTime())
+	}
+
+	targetInfo, err := os.Stat(path.Join(src, "folder1/folder2/folder3/file3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if targetInfo.ModTime().Equal(linkMtime) {
+		t.Error("link target's mtime changed; WithNoFollow must retime the link, not its target")
+	}
+}
@@ -0,0 +1,58 @@
+//go:build linux || darwin
+
+package copy
+
+import (
+	"io/fs"
+	"os"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// applyOwner chowns dst to info's uid/gid. A permission error is swallowed
+// unless strict is set, since only root can change ownership arbitrarily.
+func applyOwner(dst string, info fs.FileInfo, follow, strict bool) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+
+	chown := os.Chown
+	if !follow {
+		chown = os.Lchown
+	}
+
+	return ignorePermError(chown(dst, int(stat.Uid), int(stat.Gid)), strict)
+}
+
+// lchtimes retimes a symlink itself, without following it, via
+// UtimesNanoAt(AT_SYMLINK_NOFOLLOW).
+func lchtimes(dst string, atime, mtime time.Time) error {
+	ts := []unix.Timespec{
+		unix.NsecToTimespec(atime.UnixNano()),
+		unix.NsecToTimespec(mtime.UnixNano()),
+	}
+
+	return unix.UtimesNanoAt(unix.AT_FDCWD, dst, ts, unix.AT_SYMLINK_NOFOLLOW)
+}
+
+// splitXattrNames splits the NUL-separated name list returned by Listxattr.
+func splitXattrNames(buf []byte) []string {
+	var names []string
+
+	start := 0
+
+	for i, b := range buf {
+		if b == 0 {
+			if i > start {
+				names = append(names, string(buf[start:i]))
+			}
+
+			start = i + 1
+		}
+	}
+
+	return names
+}
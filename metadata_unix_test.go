@@ -0,0 +1,130 @@
+//go:build linux || darwin
+
+package copy
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path"
+	"syscall"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func fileOwner(info fs.FileInfo) (uid, gid uint32) {
+	stat := info.Sys().(*syscall.Stat_t)
+	return stat.Uid, stat.Gid
+}
+
+// TestCopyPreserveOwner checks that PreserveOwner chowns dst to src's
+// uid/gid. Chowning to the caller's own uid/gid needs no privileges, so
+// this runs without requiring root.
+func TestCopyPreserveOwner(t *testing.T) {
+	src, err := createSourceTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+
+	dst := path.Join(os.TempDir(), "destination-preserve-owner")
+	defer os.RemoveAll(dst)
+
+	if err := Copy(t.Context(), src, dst, WithPreserve(PreserveOwner)); err != nil {
+		t.Fatal(err)
+	}
+
+	srcInfo, err := os.Stat(path.Join(src, "file1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dstInfo, err := os.Stat(path.Join(dst, "file1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srcUID, srcGID := fileOwner(srcInfo)
+	dstUID, dstGID := fileOwner(dstInfo)
+
+	if srcUID != dstUID || srcGID != dstGID {
+		t.Errorf("owner: want %d:%d; got %d:%d", srcUID, srcGID, dstUID, dstGID)
+	}
+}
+
+// TestCopyPreserveXattrs checks that PreserveXattrs copies a regular
+// file's extended attributes onto dst.
+func TestCopyPreserveXattrs(t *testing.T) {
+	src, err := createSourceTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+
+	dst := path.Join(os.TempDir(), "destination-preserve-xattrs")
+	defer os.RemoveAll(dst)
+
+	srcFile := path.Join(src, "file1")
+
+	if err := unix.Setxattr(srcFile, "user.copy_test", []byte("hello"), 0); err != nil {
+		t.Skipf("xattrs unsupported on this filesystem: %v", err)
+	}
+
+	if err := Copy(t.Context(), src, dst, WithPreserve(PreserveXattrs)); err != nil {
+		t.Fatal(err)
+	}
+
+	value := make([]byte, 5)
+
+	if _, err := unix.Getxattr(path.Join(dst, "file1"), "user.copy_test", value); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(value, []byte("hello")) {
+		t.Errorf("xattr value: want %q; got %q", "hello", value)
+	}
+}
+
+// TestCopyXattrsFollowFalseReadsGivenPath checks that copyXattrs with
+// follow=false reads from exactly the path it's given, not some other
+// resolved path a caller might pass by mistake (the bug fixed alongside
+// this test: copyTree was calling applyMetadata with a noFollow symlink's
+// resolved target path instead of the symlink's own path). Linux's VFS
+// doesn't support extended attributes on symlinks at all (see xattr(7)),
+// so this can't be exercised through a real symlink the way
+// [TestCopyPreserveXattrs] exercises a regular file; two regular files
+// stand in for "the symlink's own path" and "its resolved target" instead.
+func TestCopyXattrsFollowFalseReadsGivenPath(t *testing.T) {
+	dir := t.TempDir()
+
+	linkPath, targetPath, dstPath := path.Join(dir, "link"), path.Join(dir, "target"), path.Join(dir, "dst")
+
+	for _, p := range []string{linkPath, targetPath, dstPath} {
+		if err := os.WriteFile(p, testData, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := unix.Setxattr(linkPath, "user.copy_test", []byte("from-link"), 0); err != nil {
+		t.Skipf("xattrs unsupported on this filesystem: %v", err)
+	}
+
+	if err := unix.Setxattr(targetPath, "user.copy_test", []byte("from-target"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := copyXattrs(linkPath, dstPath, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+
+	value := make([]byte, len("from-link"))
+
+	if _, err := unix.Getxattr(dstPath, "user.copy_test", value); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(value, []byte("from-link")) {
+		t.Errorf("xattr value: want %q (the given path's own); got %q", "from-link", value)
+	}
+}
@@ -0,0 +1,53 @@
+//go:build windows
+
+package copy
+
+import (
+	"io/fs"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// statTimes extracts atime/mtime from info's underlying
+// [syscall.Win32FileAttributeData], which is what os.Stat/os.Lstat return.
+func statTimes(info fs.FileInfo) (atime, mtime time.Time) {
+	data, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return info.ModTime(), info.ModTime()
+	}
+
+	return time.Unix(0, data.LastAccessTime.Nanoseconds()),
+		time.Unix(0, data.LastWriteTime.Nanoseconds())
+}
+
+// applyOwner is a no-op on Windows: ownership is carried by a security
+// descriptor, not a uid/gid pair, and changing it needs privileges most
+// callers won't have. PreserveOwner is accepted but does nothing here.
+func applyOwner(_ string, _ fs.FileInfo, _, _ bool) error { return nil }
+
+// lchtimes retimes a reparse point (symlink) itself on Windows.
+func lchtimes(dst string, atime, mtime time.Time) error {
+	h, err := windows.CreateFile(
+		windows.StringToUTF16Ptr(dst),
+		windows.FILE_WRITE_ATTRIBUTES,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE,
+		nil,
+		windows.OPEN_EXISTING,
+		windows.FILE_FLAG_BACKUP_SEMANTICS|windows.FILE_FLAG_OPEN_REPARSE_POINT,
+		0,
+	)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(h)
+
+	a, m := windows.NsecToFiletime(atime.UnixNano()), windows.NsecToFiletime(mtime.UnixNano())
+
+	return windows.SetFileTime(h, nil, &a, &m)
+}
+
+// copyXattrs is a no-op on Windows; xattrs and POSIX ACLs have no
+// equivalent in NTFS's alternate-data-stream/security-descriptor model.
+func copyXattrs(_, _ string, _, _, _ bool) error { return nil }
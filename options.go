@@ -1,16 +1,42 @@
 package copy
 
+import (
+	"runtime"
+
+	"github.com/emar-kar/copy/v2/internal/patternmatch"
+)
+
 const defaultBufSize = 64 * 1024
 
 type (
-	optFunc     func(*options)
-	excludeFunc func(string) bool
+	optFunc      func(*options)
+	excludeFunc  func(string) bool
+	progressFunc func(path string, bytes int64)
 
 	options struct {
 		excludeFunc excludeFunc
 		bufSize     int
 		force       bool
 		noFollow    bool
+
+		includePatterns []string
+		excludePatterns []string
+		include         *patternmatch.Matcher
+		exclude         *patternmatch.Matcher
+
+		checksum *checksumState
+
+		concurrency int
+		progress    progressFunc
+
+		noReflink      bool
+		forceUserspace bool
+
+		preserve PreserveOpts
+		dirTimes []dirTime
+
+		sourceFS FS
+		destFS   FS
 	}
 )
 
@@ -18,7 +44,54 @@ func defaultOptions() *options {
 	return &options{
 		excludeFunc: func(_ string) bool { return false },
 		bufSize:     defaultBufSize,
+		concurrency: runtime.GOMAXPROCS(0),
+		sourceFS:    OSFS{},
+		destFS:      OSFS{},
+	}
+}
+
+// compilePatterns compiles includePatterns/excludePatterns once per Copy
+// call and caches the result on o.
+func (o *options) compilePatterns() error {
+	if len(o.includePatterns) > 0 {
+		m, err := patternmatch.New(o.includePatterns)
+		if err != nil {
+			return err
+		}
+
+		o.include = m
+	}
+
+	if len(o.excludePatterns) > 0 {
+		m, err := patternmatch.New(o.excludePatterns)
+		if err != nil {
+			return err
+		}
+
+		o.exclude = m
+	}
+
+	return nil
+}
+
+// passes reports whether rel, a path relative to the source tree root,
+// passes the configured include/exclude patterns.
+func (o *options) passes(rel string) (bool, error) {
+	if o.include != nil {
+		ok, err := o.include.Match(rel)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	if o.exclude != nil {
+		ok, err := o.exclude.Match(rel)
+		if err != nil || ok {
+			return false, err
+		}
 	}
+
+	return true, nil
 }
 
 // WithBufferSize allows to set custom buffer size for file copy.
@@ -40,6 +113,84 @@ func WithExcludeFunc(fn excludeFunc) optFunc {
 	}
 }
 
+// WithIncludePatterns restricts the copy to paths matching at least one of
+// the given .dockerignore-style patterns, relative to src. A directory is
+// pruned from the walk (and not mirrored into dst) once it's provable that
+// none of its descendants can match; otherwise it's still created even if
+// none of its currently-known children match, since a pattern like "**/foo"
+// could match at any depth below it. Patterns are compiled once per [Copy]
+// call.
+func WithIncludePatterns(patterns []string) optFunc {
+	return func(o *options) { o.includePatterns = patterns }
+}
+
+// WithExcludePatterns skips paths matching any of the given
+// .dockerignore-style patterns, relative to src. A pattern prefixed with
+// "!" re-includes a path previously excluded by an earlier pattern.
+// Patterns are compiled once per [Copy] call.
+func WithExcludePatterns(patterns []string) optFunc {
+	return func(o *options) { o.excludePatterns = patterns }
+}
+
+// WithChecksum makes [Copy] digest every copied entry with algo and fill
+// result with a per-file digest map plus an aggregate digest of the whole
+// tree once the copy succeeds. result must not be nil.
+func WithChecksum(algo ChecksumAlgo, result *CopyResult) optFunc {
+	return func(o *options) {
+		o.checksum = newChecksumState(algo, result)
+	}
+}
+
+// WithConcurrency sets how many files [Copy] copies in parallel while
+// walking a directory tree. If n <= 0, the default of
+// runtime.GOMAXPROCS(0) is used.
+func WithConcurrency(n int) optFunc {
+	return func(o *options) {
+		if n <= 0 {
+			return
+		}
+
+		o.concurrency = n
+	}
+}
+
+// WithProgress registers fn to be called after every file or symlink is
+// copied, reporting its path relative to src and the number of bytes
+// written. fn may be called concurrently and must be safe for that.
+func WithProgress(fn progressFunc) optFunc {
+	return func(o *options) { o.progress = fn }
+}
+
+// WithNoReflink disables the FICLONE reflink (Linux) and clonefile (Darwin)
+// fast paths, keeping the copy_file_range in-kernel copy on Linux,
+// CopyFileExW on Windows and the buffered fallback.
+func WithNoReflink(o *options) { o.noReflink = true }
+
+// WithForceUserspace disables every platform fast path, forcing [Copy] to
+// always use the buffered copy loop. Use this when the destination must be
+// a genuine independent copy, e.g. reflinked copies would defeat the
+// purpose of a backup.
+func WithForceUserspace(o *options) { o.forceUserspace = true }
+
+// WithPreserve makes [Copy] carry over the metadata selected by p (mode,
+// owner, timestamps, xattrs and/or ACLs) from src to dst. See
+// [PreserveAll] and [PreserveStrict].
+func WithPreserve(p PreserveOpts) optFunc {
+	return func(o *options) { o.preserve = p }
+}
+
+// WithSourceFS makes [Copy] read src through fsys instead of the real
+// filesystem, e.g. an embedded fs.FS via [FSFromIOFS] or a [MemFS].
+func WithSourceFS(fsys FS) optFunc {
+	return func(o *options) { o.sourceFS = fsys }
+}
+
+// WithDestFS makes [Copy] write dst through fsys instead of the real
+// filesystem, e.g. a [MemFS].
+func WithDestFS(fsys FS) optFunc {
+	return func(o *options) { o.destFS = fsys }
+}
+
 // Force rewrites destination if it already exists.
 func Force(o *options) { o.force = true }
 